@@ -0,0 +1,380 @@
+package project
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	artifactoryCommandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+// Supported values for ProjectInitCommand.SetGenerateCI.
+const (
+	CIPlatformGithub  = "github"
+	CIPlatformGitlab  = "gitlab"
+	CIPlatformJenkins = "jenkins"
+	CIPlatformAzure   = "azure"
+)
+
+const frogbotConfigDir = ".frogbot"
+
+// ciActionInput is a single "with:" input rendered under a setup action's step, kept as an
+// ordered slice rather than a map so the generated YAML is deterministic.
+type ciActionInput struct {
+	key   string
+	value string
+}
+
+// ciSetupAction is the GitHub Action that installs a technology's build tool, plus whatever
+// "with:" inputs that action requires - e.g. actions/setup-java fails with "Input required and
+// not supplied: distribution" if its distribution input is left unset.
+type ciSetupAction struct {
+	uses string
+	with []ciActionInput
+}
+
+// ciSetupActions maps a technology to the GitHub Action that installs its build tool, used by
+// the generated Frogbot workflow so `jf audit`/`jf scan` have a toolchain to run with.
+var ciSetupActions = map[coreutils.Technology]ciSetupAction{
+	coreutils.Maven: {uses: "actions/setup-java@v3", with: []ciActionInput{
+		{"distribution", "temurin"}, {"java-version", "17"},
+	}},
+	coreutils.Gradle: {uses: "actions/setup-java@v3", with: []ciActionInput{
+		{"distribution", "temurin"}, {"java-version", "17"},
+	}},
+	coreutils.Npm: {uses: "actions/setup-node@v3", with: []ciActionInput{
+		{"node-version", "20"},
+	}},
+	coreutils.Go: {uses: "actions/setup-go@v4", with: []ciActionInput{
+		{"go-version", "1.21"},
+	}},
+	coreutils.Pip: {uses: "actions/setup-python@v4", with: []ciActionInput{
+		{"python-version", "3.11"},
+	}},
+	coreutils.Pipenv: {uses: "actions/setup-python@v4", with: []ciActionInput{
+		{"python-version", "3.11"},
+	}},
+	coreutils.Nuget: {uses: "actions/setup-dotnet@v3", with: []ciActionInput{
+		{"dotnet-version", "7.0.x"},
+	}},
+	coreutils.Cocoapods: {uses: "ruby/setup-ruby@v1", with: []ciActionInput{
+		{"ruby-version", "3.2"},
+	}},
+}
+
+// ciSetupCommands maps a technology to the shell command that installs its build tool, used by
+// the GitLab/Jenkins/Azure scaffolding. GitHub Actions has its own action-based equivalent,
+// ciSetupActions, since it doesn't run scaffolding on a plain shell image.
+var ciSetupCommands = map[coreutils.Technology]string{
+	coreutils.Maven:     "apt-get update && apt-get install -y maven",
+	coreutils.Gradle:    "apt-get update && apt-get install -y gradle",
+	coreutils.Npm:       "apt-get update && apt-get install -y npm",
+	coreutils.Go:        "apt-get update && apt-get install -y golang",
+	coreutils.Pip:       "apt-get update && apt-get install -y python3-pip",
+	coreutils.Pipenv:    "pip install pipenv",
+	coreutils.Nuget:     "apt-get update && apt-get install -y dotnet-sdk-7.0",
+	coreutils.Cocoapods: "gem install cocoapods",
+}
+
+// FrogbotConfig mirrors the subset of one repository entry in .frogbot/frogbot-config.yml that
+// ProjectInitCommand knows how to populate from the project it just initialized. Frogbot's
+// config schema is a top-level list of these entries, one per repository, so writeFrogbotConfig
+// marshals a single-element []FrogbotConfig rather than a bare mapping.
+type FrogbotConfig struct {
+	Version  int                `yaml:"version,omitempty"`
+	ServerId string             `yaml:"jfrogPlatformServerId,omitempty"`
+	Params   FrogbotConfigScans `yaml:"params,omitempty"`
+}
+
+type FrogbotConfigScans struct {
+	Scan FrogbotConfigScan `yaml:"scan,omitempty"`
+}
+
+type FrogbotConfigScan struct {
+	Technologies []string `yaml:"technologies,omitempty"`
+	// ResolverRepos maps a detected technology (lowercase) to the resolver repository already
+	// written to .jfrog/projects/<tech>.yaml, so Frogbot resolves dependencies the same way
+	// `jf audit` does instead of falling back to its own defaults.
+	ResolverRepos map[string]string `yaml:"resolverRepos,omitempty"`
+}
+
+// SetGenerateCI opts ProjectInitCommand.Run into writing Frogbot CI scaffolding for platform,
+// one of CIPlatformGithub, CIPlatformGitlab, CIPlatformJenkins or CIPlatformAzure.
+func (pic *ProjectInitCommand) SetGenerateCI(platform string) *ProjectInitCommand {
+	pic.ciPlatform = platform
+	return pic
+}
+
+// createCIMessage returns a note for the init summary about the CI scaffolding that was
+// generated, or an empty string if SetGenerateCI wasn't used.
+func (pic *ProjectInitCommand) createCIMessage() string {
+	if pic.ciPlatform == "" {
+		return ""
+	}
+	return coreutils.PrintTitle("Frogbot is configured to scan this project on "+pic.ciPlatform) +
+		"\n" +
+		"Add the JF_URL, JF_ACCESS_TOKEN and JF_GIT_TOKEN secrets to your CI provider to complete the setup.\n\n"
+}
+
+// createCurationMessage returns a hint for the init summary about running curation audits,
+// reflecting what createRemoteRepo actually managed to provision rather than just the user's
+// intent. It returns an empty string if SetEnableCuration wasn't used, or if no curation-aware
+// technology was detected, so nothing was ever attempted.
+func (pic *ProjectInitCommand) createCurationMessage() string {
+	if !pic.curation || len(pic.curationOutcomes) == 0 {
+		return ""
+	}
+	var curated, fellBack []string
+	for tech, applied := range pic.curationOutcomes {
+		if applied {
+			curated = append(curated, string(tech))
+		} else {
+			fellBack = append(fellBack, string(tech))
+		}
+	}
+	sort.Strings(curated)
+	sort.Strings(fellBack)
+
+	message := ""
+	if len(curated) > 0 {
+		message += coreutils.PrintTitle("Curated remote repositories were provisioned for: "+strings.Join(curated, ", ")) +
+			"\n" +
+			"Audit your dependencies against your curation policies by running\n" +
+			"jf curation-audit\n\n"
+	}
+	if len(fellBack) > 0 {
+		message += coreutils.PrintTitle("Curation could not be enabled for: "+strings.Join(fellBack, ", ")) +
+			"\n" +
+			"Regular remote repositories were provisioned for them instead - check your curation entitlement and permissions.\n\n"
+	}
+	return message
+}
+
+// generateCIScaffolding writes the Frogbot workflow/pipeline file(s) for pic.ciPlatform plus
+// .frogbot/frogbot-config.yml, wiring in the technologies detected by entries.
+func (pic *ProjectInitCommand) generateCIScaffolding(entries []ModuleInitializer) error {
+	if pic.ciPlatform == "" {
+		return nil
+	}
+	if err := writeFrogbotConfig(pic.projectPath, pic.serverId, entries); err != nil {
+		return err
+	}
+	initializers := initializersOf(entries)
+	switch pic.ciPlatform {
+	case CIPlatformGithub:
+		return writeGithubFrogbotWorkflows(pic.projectPath, initializers)
+	case CIPlatformGitlab:
+		return writeGitlabFrogbotPipeline(pic.projectPath, initializers)
+	case CIPlatformJenkins:
+		return writeJenkinsFrogbotStage(pic.projectPath, initializers)
+	case CIPlatformAzure:
+		return writeAzureFrogbotPipeline(pic.projectPath, initializers)
+	default:
+		return errorutils.CheckErrorf("unsupported CI platform '%s', expected one of: %s, %s, %s, %s",
+			pic.ciPlatform, CIPlatformGithub, CIPlatformGitlab, CIPlatformJenkins, CIPlatformAzure)
+	}
+}
+
+// initializersOf strips the module path off every entry, for the CI writers that only care which
+// technologies were detected and not which module each one lives in.
+func initializersOf(entries []ModuleInitializer) []TechnologyInitializer {
+	initializers := make([]TechnologyInitializer, len(entries))
+	for i, entry := range entries {
+		initializers[i] = entry.Initializer
+	}
+	return initializers
+}
+
+// resolverRepoOf reads back the resolver repository that WriteBuildConfig already wrote to
+// modulePath/.jfrog/projects/<tech>.yaml, or "" if it can't be read (e.g. the technology's
+// build config wasn't written, or doesn't set a resolver).
+func resolverRepoOf(modulePath string, tech coreutils.Technology) string {
+	techName := strings.ToLower(string(tech))
+	content, err := ioutil.ReadFile(filepath.Join(modulePath, ".jfrog", "projects", techName+".yaml"))
+	if err != nil {
+		return ""
+	}
+	var configFile artifactoryCommandsUtils.ConfigFile
+	if err := yaml.Unmarshal(content, &configFile); err != nil {
+		return ""
+	}
+	if configFile.Resolver.Repo != "" {
+		return configFile.Resolver.Repo
+	}
+	return configFile.Resolver.ReleaseRepo
+}
+
+func writeFrogbotConfig(projectPath, serverId string, entries []ModuleInitializer) error {
+	dir := filepath.Join(projectPath, frogbotConfigDir)
+	if err := fileutils.CreateDirIfNotExist(dir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	technologies := make([]string, 0, len(entries))
+	resolverRepos := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		techName := strings.ToLower(string(entry.Initializer.Technology()))
+		technologies = append(technologies, techName)
+		modulePath := filepath.Join(projectPath, entry.ModulePath)
+		if repo := resolverRepoOf(modulePath, entry.Initializer.Technology()); repo != "" {
+			resolverRepos[techName] = repo
+		}
+	}
+	config := []FrogbotConfig{{
+		Version:  1,
+		ServerId: serverId,
+		Params:   FrogbotConfigScans{Scan: FrogbotConfigScan{Technologies: technologies, ResolverRepos: resolverRepos}},
+	}}
+	resBytes, err := yaml.Marshal(config)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return writeFile(filepath.Join(dir, "frogbot-config.yml"), resBytes)
+}
+
+func writeFile(path string, content []byte) error {
+	return errorutils.CheckError(ioutil.WriteFile(path, content, 0644))
+}
+
+// setupStepsYaml renders the "- uses: <action>" steps for every detected technology's build
+// tool, deduplicating actions shared by more than one technology (e.g. Maven and Gradle), and
+// including whatever "with:" inputs that action requires.
+func setupStepsYaml(initializers []TechnologyInitializer, indent string) string {
+	seen := map[string]bool{}
+	var steps strings.Builder
+	for _, initializer := range initializers {
+		action, ok := ciSetupActions[initializer.Technology()]
+		if !ok || seen[action.uses] {
+			continue
+		}
+		seen[action.uses] = true
+		steps.WriteString(fmt.Sprintf("%s- uses: %s\n", indent, action.uses))
+		if len(action.with) > 0 {
+			steps.WriteString(indent + "  with:\n")
+			for _, input := range action.with {
+				steps.WriteString(fmt.Sprintf("%s    %s: '%s'\n", indent, input.key, input.value))
+			}
+		}
+	}
+	return steps.String()
+}
+
+// setupCommands returns the shell commands that install every detected technology's build tool,
+// deduplicating identical commands (Maven and Gradle install different packages, so both are kept), for
+// platforms that scaffold onto a plain shell image instead of a marketplace action.
+func setupCommands(initializers []TechnologyInitializer) []string {
+	seen := map[string]bool{}
+	var commands []string
+	for _, initializer := range initializers {
+		command, ok := ciSetupCommands[initializer.Technology()]
+		if !ok || seen[command] {
+			continue
+		}
+		seen[command] = true
+		commands = append(commands, command)
+	}
+	return commands
+}
+
+func writeGithubFrogbotWorkflows(projectPath string, initializers []TechnologyInitializer) error {
+	dir := filepath.Join(projectPath, ".github", "workflows")
+	if err := fileutils.CreateDirIfNotExist(dir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	setupSteps := setupStepsYaml(initializers, "      ")
+
+	scanPR := "name: \"Frogbot Scan Pull Request\"\n" +
+		"on:\n" +
+		"  pull_request_target:\n" +
+		"    types: [opened, synchronize]\n" +
+		"permissions:\n" +
+		"  contents: read\n" +
+		"  pull-requests: write\n" +
+		"jobs:\n" +
+		"  scan-pull-request:\n" +
+		"    runs-on: ubuntu-latest\n" +
+		"    steps:\n" +
+		"      - uses: actions/checkout@v4\n" +
+		setupSteps +
+		"      - uses: jfrog/frogbot@v2\n" +
+		"        env:\n" +
+		"          JF_URL: ${{ secrets.JF_URL }}\n" +
+		"          JF_ACCESS_TOKEN: ${{ secrets.JF_ACCESS_TOKEN }}\n" +
+		"          JF_GIT_TOKEN: ${{ secrets.JF_GIT_TOKEN }}\n"
+	if err := writeFile(filepath.Join(dir, "frogbot-scan-pr.yml"), []byte(scanPR)); err != nil {
+		return err
+	}
+
+	scanRepo := "name: \"Frogbot Scan Repository\"\n" +
+		"on:\n" +
+		"  push:\n" +
+		"    branches: [main, master]\n" +
+		"  workflow_dispatch:\n" +
+		"permissions:\n" +
+		"  contents: read\n" +
+		"jobs:\n" +
+		"  scan-repository:\n" +
+		"    runs-on: ubuntu-latest\n" +
+		"    steps:\n" +
+		"      - uses: actions/checkout@v4\n" +
+		setupSteps +
+		"      - uses: jfrog/frogbot@v2\n" +
+		"        env:\n" +
+		"          JF_URL: ${{ secrets.JF_URL }}\n" +
+		"          JF_ACCESS_TOKEN: ${{ secrets.JF_ACCESS_TOKEN }}\n" +
+		"          JF_GIT_TOKEN: ${{ secrets.JF_GIT_TOKEN }}\n"
+	return writeFile(filepath.Join(dir, "frogbot-scan-repo.yml"), []byte(scanRepo))
+}
+
+func writeGitlabFrogbotPipeline(projectPath string, initializers []TechnologyInitializer) error {
+	var setupScript strings.Builder
+	for _, command := range setupCommands(initializers) {
+		setupScript.WriteString("    - " + command + "\n")
+	}
+	content := "frogbot-scan:\n" +
+		"  stage: test\n" +
+		"  image: releases-docker.jfrog.io/frogbot:latest\n" +
+		"  script:\n" +
+		setupScript.String() +
+		"    - frogbot scan-pull-request\n" +
+		"  rules:\n" +
+		"    - if: $CI_PIPELINE_SOURCE == \"merge_request_event\"\n"
+	return writeFile(filepath.Join(projectPath, ".gitlab-ci-frogbot.yml"), []byte(content))
+}
+
+func writeJenkinsFrogbotStage(projectPath string, initializers []TechnologyInitializer) error {
+	var setupSteps strings.Builder
+	for _, command := range setupCommands(initializers) {
+		setupSteps.WriteString("        sh '" + command + "'\n")
+	}
+	content := "stage('Frogbot Scan') {\n" +
+		"    steps {\n" +
+		setupSteps.String() +
+		"        sh 'curl -fLs https://install.jfrog.io/v2/non-cloud | sh'\n" +
+		"        sh 'frogbot scan-repository'\n" +
+		"    }\n" +
+		"}\n"
+	return writeFile(filepath.Join(projectPath, "Jenkinsfile.frogbot"), []byte(content))
+}
+
+func writeAzureFrogbotPipeline(projectPath string, initializers []TechnologyInitializer) error {
+	var setupSteps strings.Builder
+	for _, command := range setupCommands(initializers) {
+		setupSteps.WriteString("      " + command + "\n")
+	}
+	content := "steps:\n" +
+		"  - script: |\n" +
+		setupSteps.String() +
+		"      curl -fLs https://install.jfrog.io/v2/non-cloud | sh\n" +
+		"      ./frogbot scan-pull-request\n" +
+		"    env:\n" +
+		"      JF_URL: $(JF_URL)\n" +
+		"      JF_ACCESS_TOKEN: $(JF_ACCESS_TOKEN)\n" +
+		"      JF_GIT_TOKEN: $(JF_GIT_TOKEN)\n"
+	return writeFile(filepath.Join(projectPath, "azure-pipelines-frogbot.yml"), []byte(content))
+}
@@ -0,0 +1,183 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSetupStepsYaml(t *testing.T) {
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Maven}, &fakeInitializer{tech: coreutils.Gradle}, &fakeInitializer{tech: coreutils.Npm}}
+	steps := setupStepsYaml(initializers, "  ")
+	// Maven and Gradle share a setup action, so it's only rendered once.
+	assert.Equal(t, "  - uses: actions/setup-java@v3\n"+
+		"    with:\n"+
+		"      distribution: 'temurin'\n"+
+		"      java-version: '17'\n"+
+		"  - uses: actions/setup-node@v3\n"+
+		"    with:\n"+
+		"      node-version: '20'\n", steps)
+}
+
+func TestSetupStepsYamlUnknownTechnology(t *testing.T) {
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Technology("unknown")}}
+	assert.Empty(t, setupStepsYaml(initializers, "  "))
+}
+
+func TestSetupCommands(t *testing.T) {
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Maven}, &fakeInitializer{tech: coreutils.Gradle}, &fakeInitializer{tech: coreutils.Pipenv}}
+	commands := setupCommands(initializers)
+	// Maven and Gradle install different packages, so each gets its own command.
+	assert.Equal(t, []string{ciSetupCommands[coreutils.Maven], ciSetupCommands[coreutils.Gradle], ciSetupCommands[coreutils.Pipenv]}, commands)
+}
+
+func TestSetupCommandsUnknownTechnology(t *testing.T) {
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Technology("unknown")}}
+	assert.Empty(t, setupCommands(initializers))
+}
+
+func TestWriteFrogbotConfigIsATopLevelList(t *testing.T) {
+	projectPath := t.TempDir()
+	entries := []ModuleInitializer{{Initializer: &fakeInitializer{tech: coreutils.Npm}}}
+
+	assert.NoError(t, writeFrogbotConfig(projectPath, "test-server", entries))
+
+	content := readFile(t, filepath.Join(projectPath, frogbotConfigDir, "frogbot-config.yml"))
+	var parsed []FrogbotConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(content), &parsed))
+	assert.Len(t, parsed, 1)
+	assert.Equal(t, "test-server", parsed[0].ServerId)
+	assert.Equal(t, []string{"npm"}, parsed[0].Params.Scan.Technologies)
+}
+
+// TestWriteFrogbotConfigResolvesRepoFromModuleSubdirectory proves resolverRepos is populated for
+// a technology whose build config lives under a module subdirectory, not just at the project
+// root - the layout chunk0-7's monorepo support writes for any module other than the root.
+func TestWriteFrogbotConfigResolvesRepoFromModuleSubdirectory(t *testing.T) {
+	projectPath := t.TempDir()
+	modulePath := filepath.Join(projectPath, "services", "api")
+	assert.NoError(t, os.MkdirAll(filepath.Join(modulePath, ".jfrog", "projects"), 0755))
+	assert.NoError(t, os.WriteFile(
+		filepath.Join(modulePath, ".jfrog", "projects", "go.yaml"),
+		[]byte("resolver:\n  repo: go-remote\n"),
+		0644,
+	))
+	entries := []ModuleInitializer{{Initializer: &fakeInitializer{tech: coreutils.Go}, ModulePath: filepath.Join("services", "api")}}
+
+	assert.NoError(t, writeFrogbotConfig(projectPath, "test-server", entries))
+
+	content := readFile(t, filepath.Join(projectPath, frogbotConfigDir, "frogbot-config.yml"))
+	var parsed []FrogbotConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(content), &parsed))
+	assert.Equal(t, "go-remote", parsed[0].Params.Scan.ResolverRepos["go"])
+}
+
+func TestWriteGithubFrogbotWorkflows(t *testing.T) {
+	projectPath := t.TempDir()
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Maven}}
+
+	assert.NoError(t, writeGithubFrogbotWorkflows(projectPath, initializers))
+
+	// actions/setup-java requires its distribution input - without it the step fails with
+	// "Input required and not supplied: distribution".
+	prContent := readFile(t, filepath.Join(projectPath, ".github", "workflows", "frogbot-scan-pr.yml"))
+	assert.Contains(t, prContent, "uses: actions/setup-java@v3")
+	assert.Contains(t, prContent, "distribution: 'temurin'")
+	assert.Contains(t, prContent, "pull_request_target")
+
+	repoContent := readFile(t, filepath.Join(projectPath, ".github", "workflows", "frogbot-scan-repo.yml"))
+	assert.Contains(t, repoContent, "uses: actions/setup-java@v3")
+	assert.Contains(t, repoContent, "distribution: 'temurin'")
+	assert.Contains(t, repoContent, "workflow_dispatch")
+}
+
+func TestWriteGitlabFrogbotPipeline(t *testing.T) {
+	projectPath := t.TempDir()
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Npm}}
+
+	assert.NoError(t, writeGitlabFrogbotPipeline(projectPath, initializers))
+
+	content := readFile(t, filepath.Join(projectPath, ".gitlab-ci-frogbot.yml"))
+	assert.Contains(t, content, ciSetupCommands[coreutils.Npm])
+	assert.Contains(t, content, "frogbot scan-pull-request")
+}
+
+func TestWriteJenkinsFrogbotStage(t *testing.T) {
+	projectPath := t.TempDir()
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Npm}}
+
+	assert.NoError(t, writeJenkinsFrogbotStage(projectPath, initializers))
+
+	content := readFile(t, filepath.Join(projectPath, "Jenkinsfile.frogbot"))
+	assert.Contains(t, content, ciSetupCommands[coreutils.Npm])
+	assert.Contains(t, content, "frogbot scan-repository")
+}
+
+func TestWriteAzureFrogbotPipeline(t *testing.T) {
+	projectPath := t.TempDir()
+	initializers := []TechnologyInitializer{&fakeInitializer{tech: coreutils.Npm}}
+
+	assert.NoError(t, writeAzureFrogbotPipeline(projectPath, initializers))
+
+	content := readFile(t, filepath.Join(projectPath, "azure-pipelines-frogbot.yml"))
+	assert.Contains(t, content, ciSetupCommands[coreutils.Npm])
+	assert.Contains(t, content, "frogbot scan-pull-request")
+
+	// The setup command is injected into a literal block scalar alongside the curl/frogbot
+	// lines that follow it - parse the produced YAML to make sure a real detected technology
+	// doesn't throw off that block's indentation.
+	var parsed struct {
+		Steps []struct {
+			Script string            `yaml:"script"`
+			Env    map[string]string `yaml:"env"`
+		} `yaml:"steps"`
+	}
+	assert.NoError(t, yaml.Unmarshal([]byte(content), &parsed))
+	assert.Len(t, parsed.Steps, 1)
+	assert.Contains(t, parsed.Steps[0].Script, ciSetupCommands[coreutils.Npm])
+	assert.Equal(t, "$(JF_URL)", parsed.Steps[0].Env["JF_URL"])
+}
+
+func TestCreateCurationMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		curation bool
+		outcomes map[coreutils.Technology]bool
+		contains []string
+		empty    bool
+	}{
+		{name: "curation disabled", curation: false, outcomes: map[coreutils.Technology]bool{coreutils.Maven: true}, empty: true},
+		{name: "curation enabled but nothing attempted", curation: true, outcomes: nil, empty: true},
+		{name: "all curated", curation: true, outcomes: map[coreutils.Technology]bool{coreutils.Maven: true, coreutils.Go: true},
+			contains: []string{"Curated remote repositories were provisioned for: " + string(coreutils.Go) + ", " + string(coreutils.Maven), "jf curation-audit"}},
+		{name: "all fell back", curation: true, outcomes: map[coreutils.Technology]bool{coreutils.Nuget: false},
+			contains: []string{"Curation could not be enabled for: " + string(coreutils.Nuget)}},
+		{name: "mixed outcomes", curation: true, outcomes: map[coreutils.Technology]bool{coreutils.Maven: true, coreutils.Nuget: false},
+			contains: []string{"Curated remote repositories were provisioned for: " + string(coreutils.Maven), "Curation could not be enabled for: " + string(coreutils.Nuget)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pic := &ProjectInitCommand{curation: tt.curation, curationOutcomes: tt.outcomes}
+			message := pic.createCurationMessage()
+			if tt.empty {
+				assert.Empty(t, message)
+				return
+			}
+			for _, substr := range tt.contains {
+				assert.Contains(t, message, substr)
+			}
+		})
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,221 @@
+package project
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// promptServerId lets the user pick one of the configured servers when none was provided
+// on the command line.
+func promptServerId() (string, error) {
+	serverIds, err := config.GetAllServerIds()
+	if err != nil {
+		return "", err
+	}
+	if len(serverIds) == 0 {
+		return "", errorutils.CheckErrorf("no servers are configured - run 'jf c add' first")
+	}
+	if len(serverIds) == 1 {
+		return serverIds[0], nil
+	}
+	fmt.Println(coreutils.PrintTitle("Select a server to use for this project:"))
+	for i, id := range serverIds {
+		fmt.Printf("%d) %s\n", i+1, id)
+	}
+	var selection string
+	if err = ioutils.ScanFromConsole("Server number", &selection, serverIds[0]); err != nil {
+		return "", err
+	}
+	if index, convErr := strconv.Atoi(strings.TrimSpace(selection)); convErr == nil && index >= 1 && index <= len(serverIds) {
+		return serverIds[index-1], nil
+	}
+	return selection, nil
+}
+
+// runInteractive walks the user through choosing resolver/deployer repositories for every
+// technology detected in every module, instead of silently creating and wiring up the
+// technology's defaults.
+//
+// Nothing lands in the project, and no repository is created on Artifactory, until the user
+// confirms: the build config for a module is first written under a scratch directory so the user
+// can review its actual content, and any default repositories the user opted into are only
+// created once confirmProjectConfig reports they agreed to keep that module's configuration.
+func (pic *ProjectInitCommand) runInteractive(modules map[string][]TechnologyInitializer) error {
+	scratchRoot, err := ioutil.TempDir("", "jfrog-project-init")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(scratchRoot)
+	}()
+
+	for _, module := range sortedModuleKeys(modules) {
+		modulePath := filepath.Join(pic.projectPath, module)
+		scratchModulePath := filepath.Join(scratchRoot, module)
+		var pendingDefaultRepos []RepoSpec
+		for _, initializer := range modules[module] {
+			repos, createDefaults, err := promptRepoSelection(initializer, pic.serverId)
+			if err != nil {
+				return err
+			}
+			if createDefaults {
+				pendingDefaultRepos = append(pendingDefaultRepos, initializer.DefaultRepos(pic.serverId)...)
+			}
+			if err = initializer.WriteBuildConfig(scratchModulePath, pic.serverId, repos); err != nil {
+				return err
+			}
+		}
+		keep, err := confirmProjectConfig(scratchModulePath)
+		if err != nil {
+			return err
+		}
+		if keep {
+			if err = pic.createReposFromSpecs(pendingDefaultRepos); err != nil {
+				return err
+			}
+			if err = copyProjectConfig(scratchModulePath, modulePath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// promptRepoSelection lets the user choose existing repositories for initializer's technology,
+// or fall back to letting ProjectInitCommand create the technology's defaults.
+func promptRepoSelection(initializer TechnologyInitializer, serverId string) (repos *RepoOverride, createDefaults bool, err error) {
+	tech := initializer.Technology()
+	fmt.Println(coreutils.PrintTitle(fmt.Sprintf("Detected %s project.", tech)))
+
+	existingRepos, err := listRepositoriesByTechnology(tech, serverId)
+	if err != nil {
+		// Artifactory may be unreachable while prototyping locally - fall back to defaults
+		// rather than aborting the whole init.
+		fmt.Println(coreutils.PrintTitle("Could not list existing repositories, falling back to defaults: " + err.Error()))
+		return nil, true, nil
+	}
+
+	var useExisting string
+	if err = ioutils.ScanFromConsole(fmt.Sprintf("Use an existing repository for %s resolution/deployment? (y/N)", tech), &useExisting, "N"); err != nil {
+		return nil, false, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(useExisting), "y") {
+		return nil, true, nil
+	}
+
+	resolver, err := promptRepoName("Resolver repository", existingRepos)
+	if err != nil {
+		return nil, false, err
+	}
+	deployer, err := promptRepoName("Deployer repository", existingRepos)
+	if err != nil {
+		return nil, false, err
+	}
+	override := &RepoOverride{Resolver: resolver, Deployer: deployer}
+	if tech == coreutils.Maven {
+		if override.ResolverSnapshot, err = promptRepoName("Resolver snapshot repository", existingRepos); err != nil {
+			return nil, false, err
+		}
+		if override.DeployerSnapshot, err = promptRepoName("Deployer snapshot repository", existingRepos); err != nil {
+			return nil, false, err
+		}
+	}
+	return override, false, nil
+}
+
+func promptRepoName(prompt string, existingRepos []string) (string, error) {
+	if len(existingRepos) > 0 {
+		fmt.Println(coreutils.PrintTitle(prompt + " - existing repositories:"))
+		for _, repo := range existingRepos {
+			fmt.Println("  " + repo)
+		}
+	}
+	var repoName string
+	err := ioutils.ScanFromConsole(prompt, &repoName, "")
+	return repoName, err
+}
+
+// listRepositoriesByTechnology returns the names of the repositories on serverId whose
+// package type matches tech.
+func listRepositoriesByTechnology(tech coreutils.Technology, serverId string) ([]string, error) {
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return nil, err
+	}
+	serviceManager, err := artifactoryUtils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	allRepos, err := serviceManager.GetAllRepositories()
+	if err != nil {
+		return nil, err
+	}
+	var matching []string
+	for _, repo := range *allRepos {
+		if strings.EqualFold(repo.PackageType, packageType(tech)) {
+			matching = append(matching, repo.Key)
+		}
+	}
+	return matching, nil
+}
+
+// confirmProjectConfig prints the content of the .jfrog/projects config files generated under
+// scratchModulePath and asks the user whether to keep them. Nothing under the real project
+// directory is touched either way - the caller is responsible for copying the files over once
+// confirmed.
+func confirmProjectConfig(scratchModulePath string) (keep bool, err error) {
+	jfrogProjectDir := filepath.Join(scratchModulePath, ".jfrog", "projects")
+	entries, err := os.ReadDir(jfrogProjectDir)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	fmt.Println(coreutils.PrintTitle("The following project config files will be generated:"))
+	for _, entry := range entries {
+		content, readErr := ioutil.ReadFile(filepath.Join(jfrogProjectDir, entry.Name()))
+		if readErr != nil {
+			return false, errorutils.CheckError(readErr)
+		}
+		fmt.Println(coreutils.PrintBold(entry.Name() + ":"))
+		fmt.Println(string(content))
+	}
+	var confirmation string
+	if err = ioutils.ScanFromConsole("Keep this configuration? (Y/n)", &confirmation, "Y"); err != nil {
+		return false, err
+	}
+	return !strings.EqualFold(strings.TrimSpace(confirmation), "n"), nil
+}
+
+// copyProjectConfig copies scratchModulePath's .jfrog/projects directory into modulePath, once
+// its content has been confirmed.
+func copyProjectConfig(scratchModulePath, modulePath string) error {
+	scratchProjectDir := filepath.Join(scratchModulePath, ".jfrog", "projects")
+	projectDir := filepath.Join(modulePath, ".jfrog", "projects")
+	if err := fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	entries, err := os.ReadDir(scratchProjectDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		content, err := ioutil.ReadFile(filepath.Join(scratchProjectDir, entry.Name()))
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(projectDir, entry.Name()), content, 0644); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
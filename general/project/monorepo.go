@@ -0,0 +1,186 @@
+package project
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+const buildFileName = "build.yaml"
+
+// BuildConfigFile is the .jfrog/projects/build.yaml descriptor written for a single module.
+// Module is empty for the module at the project root, and set to the module's subdirectory
+// (relative to the project root) for any other module.
+type BuildConfigFile struct {
+	Version    int    `yaml:"version,omitempty"`
+	ConfigType string `yaml:"type,omitempty"`
+	BuildName  string `yaml:"name,omitempty"`
+	Module     string `yaml:"module,omitempty"`
+}
+
+// discoverModules partitions the detected technologies into modules (subdirectories), so a
+// monorepo containing e.g. a Go module under services/api and an Npm package under web gets a
+// separate build config per subproject, instead of one flattened build.yaml at the root.
+//
+// Submodules are detected before the root, by walking the directory tree depth-first: a
+// subdirectory that has its own indication of a technology becomes its own module, keyed by its
+// path relative to the project root (e.g. "services/api"), and isn't descended into any further.
+// A subdirectory with no indication of its own is descended into, so a module nested arbitrarily
+// deep is still keyed by the directory that actually holds it, rather than by whichever ancestor
+// directory happened to be scanned first. The root is detected last, and only allowed a
+// recursive fallback scan of its own when no submodule claimed anything - otherwise a technology
+// that exists only inside a submodule would get falsely detected at the root too, since the
+// root's recursive scan isn't scoped to files directly at the root.
+func (pic *ProjectInitCommand) discoverModules() (map[string][]TechnologyInitializer, error) {
+	modules := map[string][]TechnologyInitializer{}
+	if err := discoverSubModules(pic.projectPath, "", modules); err != nil {
+		return nil, err
+	}
+
+	rootAllowRecursive := len(modules) == 0
+	if rootTechs := detectInitializersAt(pic.projectPath, rootAllowRecursive); len(rootTechs) > 0 {
+		modules[""] = rootTechs
+	}
+
+	return modules, nil
+}
+
+// discoverSubModules walks dirPath's subdirectories depth-first, adding an entry to modules for
+// every subdirectory that directly holds a technology's indication, keyed by its path relative
+// to the project root. A subdirectory claimed this way isn't descended into any further; one with
+// no indication of its own is, so a module nested arbitrarily deep is still found.
+func discoverSubModules(dirPath, relPath string, modules map[string][]TechnologyInitializer) error {
+	entries, err := ioutil.ReadDir(dirPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		subPath := filepath.Join(dirPath, entry.Name())
+		subRel := entry.Name()
+		if relPath != "" {
+			subRel = filepath.Join(relPath, entry.Name())
+		}
+		if subTechs := detectInitializersAt(subPath, false); len(subTechs) > 0 {
+			modules[subRel] = subTechs
+			continue
+		}
+		if err := discoverSubModules(subPath, subRel, modules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func detectInitializersAt(path string, allowRecursive bool) (initializers []TechnologyInitializer) {
+	for _, initializer := range GetRegisteredTechnologyInitializers() {
+		if initializer.Detect(path, allowRecursive) {
+			initializers = append(initializers, initializer)
+		}
+	}
+	return
+}
+
+// sortedModuleKeys returns modules' keys with the root module ("") first, followed by the rest
+// in alphabetical order, so output (build configs, summary message) is deterministic.
+func sortedModuleKeys(modules map[string][]TechnologyInitializer) []string {
+	keys := make([]string, 0, len(modules))
+	for module := range modules {
+		keys = append(keys, module)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == "" || keys[j] == "" {
+			return keys[i] == ""
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// ModuleInitializer pairs a detected TechnologyInitializer with the module (subdirectory,
+// relative to the project root - "" for the project root itself) it was detected in.
+type ModuleInitializer struct {
+	Initializer TechnologyInitializer
+	ModulePath  string
+}
+
+// flattenModules returns every distinct TechnologyInitializer detected across all modules,
+// paired with the module it was detected in, in module order. It's used where most callers don't
+// care about module boundaries, e.g. CI scaffolding that scans the whole repository regardless of
+// which module a finding is in, but some (resolverRepoOf) still need to know which module's
+// .jfrog/projects a technology's build config was actually written under.
+func flattenModules(modules map[string][]TechnologyInitializer) []ModuleInitializer {
+	seen := map[coreutils.Technology]bool{}
+	var all []ModuleInitializer
+	for _, module := range sortedModuleKeys(modules) {
+		for _, initializer := range modules[module] {
+			if seen[initializer.Technology()] {
+				continue
+			}
+			seen[initializer.Technology()] = true
+			all = append(all, ModuleInitializer{Initializer: initializer, ModulePath: module})
+		}
+	}
+	return all
+}
+
+// moduleBuildMessage renders module's build/deploy commands, prefixing each command with a cd
+// into the module's subdirectory when it isn't the project root.
+func moduleBuildMessage(module string, initializers []TechnologyInitializer) string {
+	message := ""
+	for _, initializer := range initializers {
+		message += initializer.BuildMessage()
+	}
+	if message == "" || module == "" {
+		return message
+	}
+	var prefixed strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(message, "\n"), "\n") {
+		prefixed.WriteString("cd " + module + " && " + line + "\n")
+	}
+	return prefixed.String()
+}
+
+// createBuildConfigs writes a .jfrog/projects/build.yaml under each module's subdirectory. If no
+// module was detected at all (e.g. the project uses a technology project init doesn't recognize),
+// it still writes a single build.yaml at the project root, so init always leaves a usable build
+// config behind.
+func (pic *ProjectInitCommand) createBuildConfigs(modules map[string][]TechnologyInitializer) error {
+	moduleKeys := sortedModuleKeys(modules)
+	if len(moduleKeys) == 0 {
+		moduleKeys = []string{""}
+	}
+	projectDirName := filepath.Base(pic.projectPath)
+	for _, module := range moduleKeys {
+		buildName := projectDirName
+		if module != "" {
+			buildName = projectDirName + "-" + strings.ReplaceAll(module, string(filepath.Separator), "-")
+		}
+		if err := writeBuildConfig(filepath.Join(pic.projectPath, module), buildName, module); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBuildConfig(modulePath, buildName, module string) error {
+	jfrogProjectDir := filepath.Join(modulePath, ".jfrog", "projects")
+	if err := fileutils.CreateDirIfNotExist(jfrogProjectDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	configFilePath := filepath.Join(jfrogProjectDir, buildFileName)
+	buildConfigFile := &BuildConfigFile{Version: 1, ConfigType: "build", BuildName: buildName, Module: module}
+	resBytes, err := yaml.Marshal(&buildConfigFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(configFilePath, resBytes, 0644))
+}
@@ -0,0 +1,177 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// markerFileInitializer detects a technology by the presence of a named file directly under
+// projectPath, so discoverModules can be tested without depending on coreutils.DetectTechnologies.
+// It ignores allowRecursive entirely, unlike the real technology initializers - that's exactly
+// why TestDiscoverModulesDoesNotLeakSubmoduleTechnologyIntoRoot below uses
+// recursiveMarkerFileInitializer instead.
+type markerFileInitializer struct {
+	tech       coreutils.Technology
+	markerName string
+}
+
+func (m *markerFileInitializer) Technology() coreutils.Technology { return m.tech }
+func (m *markerFileInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	_, err := os.Stat(filepath.Join(projectPath, m.markerName))
+	return err == nil
+}
+func (m *markerFileInitializer) DefaultRepos(string) []RepoSpec                       { return nil }
+func (m *markerFileInitializer) WriteBuildConfig(string, string, *RepoOverride) error { return nil }
+func (m *markerFileInitializer) BuildMessage() string                                 { return "" }
+
+// recursiveMarkerFileInitializer detects a technology the same two-phase way the real
+// coreutils-backed initializers do: a direct check of projectPath itself, falling back - only
+// when allowRecursive is set - to a recursive walk of projectPath's subtree. Unlike
+// markerFileInitializer's flat os.Stat check, this actually exercises the recursive-fallback
+// codepath that the root-leak bug lived in.
+type recursiveMarkerFileInitializer struct {
+	tech       coreutils.Technology
+	markerName string
+}
+
+func (r *recursiveMarkerFileInitializer) Technology() coreutils.Technology { return r.tech }
+func (r *recursiveMarkerFileInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	if _, err := os.Stat(filepath.Join(projectPath, r.markerName)); err == nil {
+		return true
+	}
+	if !allowRecursive {
+		return false
+	}
+	found := false
+	_ = filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || found {
+			return nil
+		}
+		if !info.IsDir() && info.Name() == r.markerName {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+func (r *recursiveMarkerFileInitializer) DefaultRepos(string) []RepoSpec { return nil }
+func (r *recursiveMarkerFileInitializer) WriteBuildConfig(string, string, *RepoOverride) error {
+	return nil
+}
+func (r *recursiveMarkerFileInitializer) BuildMessage() string { return "" }
+
+func withRegisteredInitializers(t *testing.T, initializers ...TechnologyInitializer) {
+	previous := technologyInitializers
+	technologyInitializers = map[coreutils.Technology]TechnologyInitializer{}
+	for _, initializer := range initializers {
+		RegisterTechnologyInitializer(initializer)
+	}
+	t.Cleanup(func() { technologyInitializers = previous })
+}
+
+func TestDiscoverModules(t *testing.T) {
+	withRegisteredInitializers(t, &markerFileInitializer{tech: coreutils.Npm, markerName: "package.json"})
+
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "package.json"), []byte("{}"), 0644))
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "web"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "web", "package.json"), []byte("{}"), 0644))
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "docs"), 0755))
+
+	pic := &ProjectInitCommand{projectPath: root}
+	modules, err := pic.discoverModules()
+	assert.NoError(t, err)
+	assert.Contains(t, modules, "")
+	assert.Contains(t, modules, "web")
+	assert.NotContains(t, modules, "docs")
+}
+
+func TestDiscoverModulesNoModulesDetected(t *testing.T) {
+	withRegisteredInitializers(t, &markerFileInitializer{tech: coreutils.Npm, markerName: "package.json"})
+
+	pic := &ProjectInitCommand{projectPath: t.TempDir()}
+	modules, err := pic.discoverModules()
+	assert.NoError(t, err)
+	assert.Empty(t, modules)
+}
+
+func TestDiscoverModulesPropagatesReadError(t *testing.T) {
+	pic := &ProjectInitCommand{projectPath: filepath.Join(t.TempDir(), "does-not-exist")}
+	_, err := pic.discoverModules()
+	assert.Error(t, err)
+}
+
+// TestDiscoverModulesDoesNotLeakSubmoduleTechnologyIntoRoot proves that a technology which exists
+// only inside a submodule doesn't also get detected at the project root. Using
+// recursiveMarkerFileInitializer (rather than markerFileInitializer's flat os.Stat check) means
+// the root's detection genuinely exercises the recursive fallback the bug lived in: before the
+// fix, the root was detected first with recursion always allowed, so its recursive scan reached
+// straight into web/package.json and falsely claimed Npm for the root too.
+func TestDiscoverModulesDoesNotLeakSubmoduleTechnologyIntoRoot(t *testing.T) {
+	withRegisteredInitializers(t, &recursiveMarkerFileInitializer{tech: coreutils.Npm, markerName: "package.json"})
+
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "web"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "web", "package.json"), []byte("{}"), 0644))
+
+	pic := &ProjectInitCommand{projectPath: root}
+	modules, err := pic.discoverModules()
+	assert.NoError(t, err)
+	assert.Contains(t, modules, "web")
+	assert.NotContains(t, modules, "")
+}
+
+// TestDiscoverModulesNestedModule proves that a module nested more than one directory deep, e.g.
+// a Go module at services/api, is keyed by the directory that actually holds it rather than by
+// whichever ancestor directory was scanned first.
+func TestDiscoverModulesNestedModule(t *testing.T) {
+	withRegisteredInitializers(t, &markerFileInitializer{tech: coreutils.Go, markerName: "go.mod"})
+
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "services", "api", "go.mod"), []byte("module api\n"), 0644))
+
+	pic := &ProjectInitCommand{projectPath: root}
+	modules, err := pic.discoverModules()
+	assert.NoError(t, err)
+	assert.Contains(t, modules, filepath.Join("services", "api"))
+	assert.NotContains(t, modules, "services")
+}
+
+func TestSortedModuleKeys(t *testing.T) {
+	modules := map[string][]TechnologyInitializer{
+		"web": nil,
+		"":    nil,
+		"api": nil,
+	}
+	assert.Equal(t, []string{"", "api", "web"}, sortedModuleKeys(modules))
+}
+
+func TestFlattenModulesDeduplicatesByTechnology(t *testing.T) {
+	npm := &markerFileInitializer{tech: coreutils.Npm}
+	modules := map[string][]TechnologyInitializer{
+		"":    {npm},
+		"web": {npm},
+	}
+	flattened := flattenModules(modules)
+	assert.Len(t, flattened, 1)
+	// The root module is visited first, so it wins the technology's single entry.
+	assert.Equal(t, "", flattened[0].ModulePath)
+}
+
+func TestCreateBuildConfigsFallsBackToRootWhenNoModulesDetected(t *testing.T) {
+	root := t.TempDir()
+	projectPath := filepath.Join(root, "myproj")
+	assert.NoError(t, os.Mkdir(projectPath, 0755))
+
+	pic := &ProjectInitCommand{projectPath: projectPath}
+	assert.NoError(t, pic.createBuildConfigs(map[string][]TechnologyInitializer{}))
+
+	content, err := os.ReadFile(filepath.Join(projectPath, ".jfrog", "projects", buildFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "myproj")
+}
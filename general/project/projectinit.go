@@ -2,26 +2,23 @@ package project
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
-	"strings"
 
-	artifactoryCommandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
-	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
-	"gopkg.in/yaml.v2"
-)
-
-const (
-	buildFileName = "build.yaml"
 )
 
 type ProjectInitCommand struct {
 	projectPath string
 	serverId    string
+	interactive bool
+	ciPlatform  string
+	curation    bool
+	// curationOutcomes records, for every curation-aware technology createRemoteRepo attempted
+	// to provision with curation enabled, whether that attempt actually succeeded. It's consulted
+	// by createCurationMessage so the init summary reflects what really happened rather than just
+	// the user's intent.
+	curationOutcomes map[coreutils.Technology]bool
 }
 
 func NewProjectInitCommand() *ProjectInitCommand {
@@ -38,43 +35,83 @@ func (pic *ProjectInitCommand) SetServerId(id string) *ProjectInitCommand {
 	return pic
 }
 
+// SetInteractive toggles the interactive init flow, which prompts the user to pick a server
+// (when none was set) and, per detected technology, to choose existing Artifactory
+// repositories instead of silently creating the technology's default ones.
+func (pic *ProjectInitCommand) SetInteractive(interactive bool) *ProjectInitCommand {
+	pic.interactive = interactive
+	return pic
+}
+
+// SetEnableCuration opts default repository provisioning into jfrog-cli-security's curation
+// audit: the remote repo of a curation-aware technology (Maven, Pip, Go, Nuget) is created with
+// pass-through curation enabled.
+func (pic *ProjectInitCommand) SetEnableCuration(enable bool) *ProjectInitCommand {
+	pic.curation = enable
+	return pic
+}
+
+// runDefault creates default repositories and writes the build config for every technology
+// detected in every module, without any user interaction.
+func (pic *ProjectInitCommand) runDefault(modules map[string][]TechnologyInitializer) error {
+	for _, module := range sortedModuleKeys(modules) {
+		modulePath := filepath.Join(pic.projectPath, module)
+		for _, initializer := range modules[module] {
+			// First create repositories for the detected technology.
+			if err := pic.createReposFromSpecs(initializer.DefaultRepos(pic.serverId)); err != nil {
+				return err
+			}
+			if err := initializer.WriteBuildConfig(modulePath, pic.serverId, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (pic *ProjectInitCommand) Run() (err error) {
 	if pic.serverId == "" {
-		defaultServer, err := config.GetSpecificConfig("", true, false)
+		if pic.interactive {
+			pic.serverId, err = promptServerId()
+		} else {
+			var defaultServer *config.ServerDetails
+			defaultServer, err = config.GetSpecificConfig("", true, false)
+			if err == nil {
+				pic.serverId = defaultServer.ServerId
+			}
+		}
 		if err != nil {
 			return err
 		}
-		pic.serverId = defaultServer.ServerId
 	}
-	technologiesMap, err := pic.detectTechnologies()
+	modules, err := pic.discoverModules()
 	if err != nil {
 		return err
 	}
-	// First create repositories for the detected technologies.
-	for techName := range technologiesMap {
-		// First create repositories for the detected technology.
-		err = createDefaultReposIfNeeded(techName, pic.serverId)
-		if err != nil {
-			return err
-		}
-		err = createProjectBuildConfigs(techName, pic.projectPath, pic.serverId)
-		if err != nil {
-			return err
-		}
+	if pic.interactive {
+		err = pic.runInteractive(modules)
+	} else {
+		err = pic.runDefault(modules)
+	}
+	if err != nil {
+		return err
 	}
 	// Create build config
-	if err = pic.createBuildConfig(); err != nil {
+	if err = pic.createBuildConfigs(modules); err != nil {
+		return
+	}
+	if err = pic.generateCIScaffolding(flattenModules(modules)); err != nil {
 		return
 	}
 
 	fmt.Println()
-	err = coreutils.PrintTable("", "", pic.createSummarizeMessage(technologiesMap))
+	err = coreutils.PrintTable("", "", pic.createSummarizeMessage(modules))
 	fmt.Println()
 
 	return
 }
 
-func (pic *ProjectInitCommand) createSummarizeMessage(technologiesMap map[coreutils.Technology]bool) string {
+func (pic *ProjectInitCommand) createSummarizeMessage(modules map[string][]TechnologyInitializer) string {
 	return coreutils.PrintBold("This project is initialized!\n") +
 		coreutils.PrintBold("The project config is stored inside the .jfrog directory.") +
 		"\n\n" +
@@ -90,7 +127,9 @@ func (pic *ProjectInitCommand) createSummarizeMessage(technologiesMap map[coreut
 		"2. Install the JFrog extension or plugin\n" +
 		"3. View the JFrog panel" +
 		"\n\n" +
-		pic.createBuildMessage(technologiesMap) +
+		pic.createBuildMessage(modules) +
+		pic.createCurationMessage() +
+		pic.createCIMessage() +
 		coreutils.PrintTitle("Read more using this link:") +
 		"\n" +
 		coreutils.PrintLink(coreutils.GettingStartedGuideUrl) +
@@ -98,35 +137,12 @@ func (pic *ProjectInitCommand) createSummarizeMessage(technologiesMap map[coreut
 		coreutils.GetFeedbackMessage()
 }
 
-// Return a string message, which includes all the build and deployment commands, matching the technologiesMap sent.
-func (pic *ProjectInitCommand) createBuildMessage(technologiesMap map[coreutils.Technology]bool) string {
+// Return a string message, which includes all the build and deployment commands for every
+// module, matching the technologies detected in each.
+func (pic *ProjectInitCommand) createBuildMessage(modules map[string][]TechnologyInitializer) string {
 	message := ""
-	for tech := range technologiesMap {
-		switch tech {
-		case coreutils.Maven:
-			message += "jf mvn install deploy\n"
-		case coreutils.Gradle:
-			message += "jf gradle artifactoryP\n"
-		case coreutils.Npm:
-			message += "jf npm install\n"
-			message += "jf npm publish\n"
-		case coreutils.Go:
-			message +=
-				"jf go build\n" +
-					"jf go-publish v1.0.0\n"
-		case coreutils.Pip:
-			message +=
-				"jf pip install\n" +
-					"jf rt u path/to/package/file default-pypi-local" +
-					coreutils.PrintComment(" # Publish your pip package") +
-					"\n"
-		case coreutils.Pipenv:
-			message +=
-				"jf pipenv install\n" +
-					"jf rt u path/to/package/file default-pypi-local" +
-					coreutils.PrintComment(" # Publish your pipenv package") +
-					"\n"
-		}
+	for _, module := range sortedModuleKeys(modules) {
+		message += moduleBuildMessage(module, modules[module])
 	}
 	if message != "" {
 		message = coreutils.PrintTitle("Build the code & deploy the packages by running") +
@@ -140,104 +156,10 @@ func (pic *ProjectInitCommand) createBuildMessage(technologiesMap map[coreutils.
 	return message
 }
 
-// Returns all detected technologies found in the project directory.
-// First, try to return only the technologies that detected according to files in the root directory.
-// In case no indication found in the root directory, the search continue recursively.
-func (pic *ProjectInitCommand) detectTechnologies() (technologiesMap map[coreutils.Technology]bool, err error) {
-	technologiesMap, err = coreutils.DetectTechnologies(pic.projectPath, false, false)
-	if err != nil {
-		return
-	}
-	// In case no technologies were detected in the root directory, try again recursively.
-	if len(technologiesMap) == 0 {
-		technologiesMap, err = coreutils.DetectTechnologies(pic.projectPath, false, true)
-		if err != nil {
-			return
-		}
-	}
-	return
-}
-
-type BuildConfigFile struct {
-	Version    int    `yaml:"version,omitempty"`
-	ConfigType string `yaml:"type,omitempty"`
-	BuildName  string `yaml:"name,omitempty"`
-}
-
-func (pic *ProjectInitCommand) createBuildConfig() error {
-	jfrogProjectDir := filepath.Join(pic.projectPath, ".jfrog", "projects")
-	if err := fileutils.CreateDirIfNotExist(jfrogProjectDir); err != nil {
-		return errorutils.CheckError(err)
-	}
-	configFilePath := filepath.Join(jfrogProjectDir, buildFileName)
-	projectDirName := filepath.Base(filepath.Dir(pic.projectPath))
-	buildConfigFile := &BuildConfigFile{Version: 1, ConfigType: "build", BuildName: projectDirName}
-	resBytes, err := yaml.Marshal(&buildConfigFile)
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-	return errorutils.CheckError(ioutil.WriteFile(configFilePath, resBytes, 0644))
-}
-
-func createDefaultReposIfNeeded(tech coreutils.Technology, serverId string) error {
-	err := CreateDefaultLocalRepo(tech, serverId)
-	if err != nil {
-		return err
-	}
-	err = CreateDefaultRemoteRepo(tech, serverId)
-	if err != nil {
-		return err
-	}
-
-	return CreateDefaultVirtualRepo(tech, serverId)
-}
-
-func createProjectBuildConfigs(tech coreutils.Technology, projectPath string, serverId string) error {
-	jfrogProjectDir := filepath.Join(projectPath, ".jfrog", "projects")
-	if err := fileutils.CreateDirIfNotExist(jfrogProjectDir); err != nil {
-		return errorutils.CheckError(err)
-	}
-	techName := strings.ToLower(string(tech))
-	configFilePath := filepath.Join(jfrogProjectDir, techName+".yaml")
-	configFile := artifactoryCommandsUtils.ConfigFile{
-		Version:    artifactoryCommandsUtils.BuildConfVersion,
-		ConfigType: techName,
-	}
-	configFile.Resolver = artifactoryUtils.Repository{ServerId: serverId}
-	configFile.Deployer = artifactoryUtils.Repository{ServerId: serverId}
-	switch tech {
-	case coreutils.Maven:
-		configFile.Resolver.ReleaseRepo = MavenVirtualDefaultName
-		configFile.Resolver.SnapshotRepo = MavenVirtualDefaultName
-		configFile.Deployer.ReleaseRepo = MavenVirtualDefaultName
-		configFile.Deployer.SnapshotRepo = MavenVirtualDefaultName
-	case coreutils.Gradle:
-		configFile.Resolver.Repo = GradleVirtualDefaultName
-		configFile.Deployer.Repo = GradleVirtualDefaultName
-	case coreutils.Npm:
-		configFile.Resolver.Repo = NpmVirtualDefaultName
-		configFile.Deployer.Repo = NpmVirtualDefaultName
-	case coreutils.Go:
-		configFile.Resolver.Repo = GoVirtualDefaultName
-		configFile.Deployer.Repo = GoVirtualDefaultName
-	case coreutils.Pipenv:
-		fallthrough
-	case coreutils.Pip:
-		configFile.Resolver.Repo = PypiVirtualDefaultName
-		configFile.Deployer.Repo = PypiVirtualDefaultName
-	}
-	resBytes, err := yaml.Marshal(&configFile)
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-
-	return errorutils.CheckError(ioutil.WriteFile(configFilePath, resBytes, 0644))
-}
-
 func (pic *ProjectInitCommand) CommandName() string {
 	return "project_init"
 }
 
 func (pic *ProjectInitCommand) ServerDetails() (*config.ServerDetails, error) {
 	return config.GetSpecificConfig("", true, false)
-}
\ No newline at end of file
+}
@@ -0,0 +1,195 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// cocoapodsSpecsRepoUrl is the CocoaPods Specs GitHub repository that Artifactory's CocoaPods
+// remote repository mirrors, rather than proxying a plain package index like the other
+// technologies' remote repos.
+const cocoapodsSpecsRepoUrl = "https://github.com/CocoaPods/Specs"
+
+// curationAwareTechnologies are the technologies jfrog-cli-security's curation audit currently
+// supports (pass-through curation on the remote repo plus technology-specific headers/behavior).
+var curationAwareTechnologies = map[coreutils.Technology]bool{
+	coreutils.Maven: true,
+	coreutils.Pip:   true,
+	coreutils.Go:    true,
+	coreutils.Nuget: true,
+}
+
+// RepoType represents the role a repository plays in a resolution/deployment chain.
+type RepoType string
+
+const (
+	RepoTypeLocal   RepoType = "local"
+	RepoTypeRemote  RepoType = "remote"
+	RepoTypeVirtual RepoType = "virtual"
+)
+
+// RepoSpec describes a default repository that a TechnologyInitializer would like created
+// before the project's build config is written. The repository's name isn't part of the spec:
+// CreateDefaultLocalRepo/CreateDefaultRemoteRepo/CreateDefaultVirtualRepo derive it from
+// PackageType and Type themselves, the same way WriteBuildConfig's *VirtualDefaultName
+// constants do.
+type RepoSpec struct {
+	Type        RepoType
+	PackageType coreutils.Technology
+}
+
+// RepoOverride lets a caller (e.g. interactive init) pin the resolver/deployer repositories a
+// TechnologyInitializer writes into the build config, instead of its virtual default. Snapshot
+// fields are only consulted by technologies that separate release and snapshot repos (Maven);
+// other technologies ignore them. A zero-value field means "keep the technology's default".
+type RepoOverride struct {
+	Resolver         string
+	Deployer         string
+	ResolverSnapshot string
+	DeployerSnapshot string
+}
+
+// orDefault returns override if it's non-empty, otherwise fallback.
+func orDefault(override, fallback string) string {
+	if override != "" {
+		return override
+	}
+	return fallback
+}
+
+// artifactoryPackageTypes maps a technology to the Artifactory repository package type used to
+// list matching repositories. Most technologies' package type is just their own lowercase name;
+// Pip and Pipenv are the exception, since Artifactory models both under the single "pypi" package
+// type (the same reason they share PypiVirtualDefaultName).
+var artifactoryPackageTypes = map[coreutils.Technology]string{
+	coreutils.Pip:    "pypi",
+	coreutils.Pipenv: "pypi",
+}
+
+// packageType returns the Artifactory repository package type used by tech.
+func packageType(tech coreutils.Technology) string {
+	if pt, ok := artifactoryPackageTypes[tech]; ok {
+		return pt
+	}
+	return strings.ToLower(string(tech))
+}
+
+// TechnologyInitializer knows how to detect a technology in a project directory and scaffold
+// everything ProjectInitCommand needs to hand that technology off to Artifactory: default
+// repositories, a build config file and the build/deploy instructions printed in the summary.
+//
+// Built-in technologies are registered in technology_defaults.go. Out-of-tree packages and
+// tests can register their own via RegisterTechnologyInitializer instead of requiring changes
+// to ProjectInitCommand itself.
+type TechnologyInitializer interface {
+	// Technology returns the coreutils.Technology this initializer handles.
+	Technology() coreutils.Technology
+	// Detect reports whether projectPath contains indication of this technology. When
+	// allowRecursive is false, only projectPath itself is considered; when true, implementations
+	// may also fall back to scanning projectPath's subtree. Callers that partition a project into
+	// modules set allowRecursive to false for a path they've already scanned one level down
+	// (e.g. the project root, once its submodules have been detected), so a technology that only
+	// exists in a submodule doesn't also get claimed by an ancestor.
+	Detect(projectPath string, allowRecursive bool) bool
+	// DefaultRepos returns the repositories that should be created, in creation order
+	// (local, then remote, then virtual).
+	DefaultRepos(serverId string) []RepoSpec
+	// WriteBuildConfig writes this technology's resolver/deployer config under
+	// filepath.Join(dir, ".jfrog", "projects"). repos may be nil, in which case the
+	// technology's virtual default repo is used for both resolver and deployer.
+	WriteBuildConfig(dir, serverId string, repos *RepoOverride) error
+	// BuildMessage returns the build/deploy instructions printed in the init summary,
+	// or an empty string if this technology doesn't participate in build-info.
+	BuildMessage() string
+}
+
+// technologyInitializers holds the registered initializers, keyed by the technology they handle.
+var technologyInitializers = map[coreutils.Technology]TechnologyInitializer{}
+
+// RegisterTechnologyInitializer registers initializer so that ProjectInitCommand.Run picks it up.
+// Registering a technology that is already registered overrides the existing initializer.
+func RegisterTechnologyInitializer(initializer TechnologyInitializer) {
+	technologyInitializers[initializer.Technology()] = initializer
+}
+
+// GetRegisteredTechnologyInitializers returns all registered initializers, sorted by technology.
+func GetRegisteredTechnologyInitializers() []TechnologyInitializer {
+	initializers := make([]TechnologyInitializer, 0, len(technologyInitializers))
+	for _, initializer := range technologyInitializers {
+		initializers = append(initializers, initializer)
+	}
+	sort.Slice(initializers, func(i, j int) bool {
+		return initializers[i].Technology() < initializers[j].Technology()
+	})
+	return initializers
+}
+
+// createReposFromSpecs creates the repositories described by specs, in order. When pic.curation
+// is set, remote repos of a curation-aware technology are provisioned with curation enabled
+// instead of the plain template; if that provisioning fails (e.g. the user lacks permission to
+// enable curation), it degrades to a plain remote repo with a warning rather than aborting the
+// whole init. Either way, the outcome is recorded in pic.curationOutcomes for createCurationMessage.
+func (pic *ProjectInitCommand) createReposFromSpecs(specs []RepoSpec) error {
+	for _, spec := range specs {
+		var err error
+		switch spec.Type {
+		case RepoTypeLocal:
+			err = CreateDefaultLocalRepo(spec.PackageType, pic.serverId)
+		case RepoTypeRemote:
+			err = pic.createRemoteRepo(spec.PackageType)
+		case RepoTypeVirtual:
+			err = CreateDefaultVirtualRepo(spec.PackageType, pic.serverId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pic *ProjectInitCommand) createRemoteRepo(tech coreutils.Technology) error {
+	// CocoaPods remotes mirror the CocoaPods Specs GitHub repository, which the generic
+	// remote-repo template doesn't know about. No CDN source URL is set.
+	if tech == coreutils.Cocoapods {
+		return CreateDefaultCocoapodsRemoteRepo(pic.serverId)
+	}
+	if pic.curation && curationAwareTechnologies[tech] {
+		if pic.curationOutcomes == nil {
+			pic.curationOutcomes = map[coreutils.Technology]bool{}
+		}
+		if err := CreateDefaultCuratedRemoteRepo(tech, pic.serverId); err != nil {
+			log.Warn(fmt.Sprintf("Failed provisioning a curated remote repository for %s, falling back to a regular remote repository: %s", tech, err.Error()))
+			pic.curationOutcomes[tech] = false
+		} else {
+			pic.curationOutcomes[tech] = true
+			return nil
+		}
+	}
+	return CreateDefaultRemoteRepo(tech, pic.serverId)
+}
+
+// CreateDefaultCocoapodsRemoteRepo creates the cocoapods-remote repository, mirroring the
+// CocoaPods Specs GitHub repository. CocoaPods has no package index of its own to proxy, so it
+// can't reuse the generic remote-repo template the other technologies rely on.
+func CreateDefaultCocoapodsRemoteRepo(serverId string) error {
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return err
+	}
+	serviceManager, err := artifactoryUtils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	params := services.NewCocoapodsRemoteRepositoryParams()
+	params.Key = "cocoapods-remote"
+	params.Url = cocoapodsSpecsRepoUrl
+	params.VcsGitProvider = "GITHUB"
+	return serviceManager.CreateRemoteRepository().Cocoapods(params)
+}
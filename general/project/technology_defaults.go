@@ -0,0 +1,430 @@
+package project
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	artifactoryCommandsUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	artifactoryUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	RegisterTechnologyInitializer(&mavenInitializer{})
+	RegisterTechnologyInitializer(&gradleInitializer{})
+	RegisterTechnologyInitializer(&npmInitializer{})
+	RegisterTechnologyInitializer(&goInitializer{})
+	RegisterTechnologyInitializer(&pipInitializer{})
+	RegisterTechnologyInitializer(&pipenvInitializer{})
+	RegisterTechnologyInitializer(&nugetInitializer{})
+	RegisterTechnologyInitializer(&cocoapodsInitializer{})
+}
+
+// technologyScanCache memoizes coreutils.DetectTechnologies by projectPath and recursive, so the
+// handful of registered TechnologyInitializers checking the same directory via detectTechnology
+// share one scan instead of each re-walking it from scratch.
+var technologyScanCache = map[string]map[coreutils.Technology]bool{}
+
+func scanTechnologiesAt(projectPath string, recursive bool) (map[coreutils.Technology]bool, error) {
+	cacheKey := projectPath
+	if recursive {
+		cacheKey += "|recursive"
+	}
+	if cached, ok := technologyScanCache[cacheKey]; ok {
+		return cached, nil
+	}
+	technologiesMap, err := coreutils.DetectTechnologies(projectPath, false, recursive)
+	if err != nil {
+		return nil, err
+	}
+	technologyScanCache[cacheKey] = technologiesMap
+	return technologiesMap, nil
+}
+
+// detectTechnology reports whether tech is found in projectPath, first by looking at the root
+// directory only and, if nothing was found there and allowRecursive is set, by searching
+// recursively.
+func detectTechnology(tech coreutils.Technology, projectPath string, allowRecursive bool) bool {
+	technologiesMap, err := scanTechnologiesAt(projectPath, false)
+	if err == nil && technologiesMap[tech] {
+		return true
+	}
+	if !allowRecursive {
+		return false
+	}
+	technologiesMap, err = scanTechnologiesAt(projectPath, true)
+	return err == nil && technologiesMap[tech]
+}
+
+// detectByMarkers reports whether any file in projectPath matches one of patterns (glob syntax,
+// matched against the file's base name), first by looking at projectPath's own entries and, if
+// nothing was found there and allowRecursive is set, by walking its subtree.
+//
+// It exists as a fallback for technologies - NuGet and CocoaPods, so far - whose markers coreutils
+// may or may not recognize yet: detectTechnology's result depends entirely on what
+// coreutils.DetectTechnologies maps to the technology, so relying on it alone risks silently never
+// detecting a technology coreutils hasn't caught up to.
+func detectByMarkers(projectPath string, patterns []string, allowRecursive bool) bool {
+	if hasMarkerAt(projectPath, patterns) {
+		return true
+	}
+	if !allowRecursive {
+		return false
+	}
+	found := false
+	_ = filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || found || info.IsDir() {
+			return nil
+		}
+		if matchesAnyPattern(info.Name(), patterns) {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+func hasMarkerAt(dir string, patterns []string) bool {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && matchesAnyPattern(entry.Name(), patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTechBuildConfig writes the .jfrog/projects/<tech>.yaml build config shared by every
+// technology, letting the caller fill in the resolver/deployer repository fields.
+func writeTechBuildConfig(tech coreutils.Technology, projectPath, serverId string, setRepos func(configFile *artifactoryCommandsUtils.ConfigFile)) error {
+	jfrogProjectDir := filepath.Join(projectPath, ".jfrog", "projects")
+	if err := fileutils.CreateDirIfNotExist(jfrogProjectDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+	techName := strings.ToLower(string(tech))
+	configFilePath := filepath.Join(jfrogProjectDir, techName+".yaml")
+	configFile := artifactoryCommandsUtils.ConfigFile{
+		Version:    artifactoryCommandsUtils.BuildConfVersion,
+		ConfigType: techName,
+	}
+	configFile.Resolver = artifactoryUtils.Repository{ServerId: serverId}
+	configFile.Deployer = artifactoryUtils.Repository{ServerId: serverId}
+	setRepos(&configFile)
+	resBytes, err := yaml.Marshal(&configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(configFilePath, resBytes, 0644))
+}
+
+type mavenInitializer struct{}
+
+func (*mavenInitializer) Technology() coreutils.Technology { return coreutils.Maven }
+
+func (*mavenInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Maven, projectPath, allowRecursive)
+}
+
+func (*mavenInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Maven},
+		{Type: RepoTypeRemote, PackageType: coreutils.Maven},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Maven},
+	}
+}
+
+func (*mavenInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolverRelease, deployerRelease := MavenVirtualDefaultName, MavenVirtualDefaultName
+	resolverSnapshot, deployerSnapshot := MavenVirtualDefaultName, MavenVirtualDefaultName
+	if repos != nil {
+		resolverRelease = orDefault(repos.Resolver, resolverRelease)
+		resolverSnapshot = orDefault(repos.ResolverSnapshot, resolverSnapshot)
+		deployerRelease = orDefault(repos.Deployer, deployerRelease)
+		deployerSnapshot = orDefault(repos.DeployerSnapshot, deployerSnapshot)
+	}
+	return writeTechBuildConfig(coreutils.Maven, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.ReleaseRepo = resolverRelease
+		configFile.Resolver.SnapshotRepo = resolverSnapshot
+		configFile.Deployer.ReleaseRepo = deployerRelease
+		configFile.Deployer.SnapshotRepo = deployerSnapshot
+	})
+}
+
+func (*mavenInitializer) BuildMessage() string {
+	return "jf mvn install deploy\n"
+}
+
+type gradleInitializer struct{}
+
+func (*gradleInitializer) Technology() coreutils.Technology { return coreutils.Gradle }
+
+func (*gradleInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Gradle, projectPath, allowRecursive)
+}
+
+func (*gradleInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Gradle},
+		{Type: RepoTypeRemote, PackageType: coreutils.Gradle},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Gradle},
+	}
+}
+
+func (*gradleInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := GradleVirtualDefaultName, GradleVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Gradle, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*gradleInitializer) BuildMessage() string {
+	return "jf gradle artifactoryP\n"
+}
+
+type npmInitializer struct{}
+
+func (*npmInitializer) Technology() coreutils.Technology { return coreutils.Npm }
+
+func (*npmInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Npm, projectPath, allowRecursive)
+}
+
+func (*npmInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Npm},
+		{Type: RepoTypeRemote, PackageType: coreutils.Npm},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Npm},
+	}
+}
+
+func (*npmInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := NpmVirtualDefaultName, NpmVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Npm, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*npmInitializer) BuildMessage() string {
+	return "jf npm install\n" + "jf npm publish\n"
+}
+
+type goInitializer struct{}
+
+func (*goInitializer) Technology() coreutils.Technology { return coreutils.Go }
+
+func (*goInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Go, projectPath, allowRecursive)
+}
+
+func (*goInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Go},
+		{Type: RepoTypeRemote, PackageType: coreutils.Go},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Go},
+	}
+}
+
+func (*goInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := GoVirtualDefaultName, GoVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Go, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*goInitializer) BuildMessage() string {
+	return "jf go build\n" + "jf go-publish v1.0.0\n"
+}
+
+type pipInitializer struct{}
+
+func (*pipInitializer) Technology() coreutils.Technology { return coreutils.Pip }
+
+func (*pipInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Pip, projectPath, allowRecursive)
+}
+
+func (*pipInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Pip},
+		{Type: RepoTypeRemote, PackageType: coreutils.Pip},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Pip},
+	}
+}
+
+func (*pipInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := PypiVirtualDefaultName, PypiVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Pip, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*pipInitializer) BuildMessage() string {
+	return "jf pip install\n" +
+		"jf rt u path/to/package/file default-pypi-local" +
+		coreutils.PrintComment(" # Publish your pip package") +
+		"\n"
+}
+
+type pipenvInitializer struct{}
+
+func (*pipenvInitializer) Technology() coreutils.Technology { return coreutils.Pipenv }
+
+func (*pipenvInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Pipenv, projectPath, allowRecursive)
+}
+
+func (*pipenvInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Pipenv},
+		{Type: RepoTypeRemote, PackageType: coreutils.Pipenv},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Pipenv},
+	}
+}
+
+func (*pipenvInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := PypiVirtualDefaultName, PypiVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Pipenv, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*pipenvInitializer) BuildMessage() string {
+	return "jf pipenv install\n" +
+		"jf rt u path/to/package/file default-pypi-local" +
+		coreutils.PrintComment(" # Publish your pipenv package") +
+		"\n"
+}
+
+// NugetVirtualDefaultName is the default virtual repository jf project init provisions for
+// NuGet projects. Unlike Maven/Gradle/Npm/Go/Pypi, NuGet support is introduced in this file,
+// so there's no pre-existing constant for it elsewhere to reuse.
+const NugetVirtualDefaultName = "nuget-virtual"
+
+// nugetMarkers are the file markers a NuGet/.NET project is expected to have, checked directly
+// via detectByMarkers alongside detectTechnology so detection doesn't depend entirely on
+// coreutils.DetectTechnologies already mapping them to coreutils.Nuget.
+var nugetMarkers = []string{"*.sln", "*.csproj", "packages.config", "*.nuspec"}
+
+type nugetInitializer struct{}
+
+func (*nugetInitializer) Technology() coreutils.Technology { return coreutils.Nuget }
+
+func (*nugetInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Nuget, projectPath, allowRecursive) ||
+		detectByMarkers(projectPath, nugetMarkers, allowRecursive)
+}
+
+func (*nugetInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Nuget},
+		{Type: RepoTypeRemote, PackageType: coreutils.Nuget},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Nuget},
+	}
+}
+
+func (*nugetInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := NugetVirtualDefaultName, NugetVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Nuget, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*nugetInitializer) BuildMessage() string {
+	return "jf nuget restore\n" +
+		"jf nuget publish" +
+		coreutils.PrintComment(" # Publish your NuGet packages") +
+		"\n"
+}
+
+// CocoapodsVirtualDefaultName is the default virtual repository jf project init provisions for
+// CocoaPods projects. Like NuGet, CocoaPods support was only just introduced above, so there's no
+// pre-existing constant for it elsewhere to reuse.
+const CocoapodsVirtualDefaultName = "cocoapods-virtual"
+
+// cocoapodsMarkers are the file markers a CocoaPods project is expected to have, checked directly
+// via detectByMarkers alongside detectTechnology so detection doesn't depend entirely on
+// coreutils.DetectTechnologies already mapping them to coreutils.Cocoapods.
+var cocoapodsMarkers = []string{"Podfile"}
+
+type cocoapodsInitializer struct{}
+
+func (*cocoapodsInitializer) Technology() coreutils.Technology { return coreutils.Cocoapods }
+
+func (*cocoapodsInitializer) Detect(projectPath string, allowRecursive bool) bool {
+	return detectTechnology(coreutils.Cocoapods, projectPath, allowRecursive) ||
+		detectByMarkers(projectPath, cocoapodsMarkers, allowRecursive)
+}
+
+func (*cocoapodsInitializer) DefaultRepos(serverId string) []RepoSpec {
+	return []RepoSpec{
+		{Type: RepoTypeLocal, PackageType: coreutils.Cocoapods},
+		{Type: RepoTypeRemote, PackageType: coreutils.Cocoapods},
+		{Type: RepoTypeVirtual, PackageType: coreutils.Cocoapods},
+	}
+}
+
+func (*cocoapodsInitializer) WriteBuildConfig(projectPath, serverId string, repos *RepoOverride) error {
+	resolver, deployer := CocoapodsVirtualDefaultName, CocoapodsVirtualDefaultName
+	if repos != nil {
+		resolver = orDefault(repos.Resolver, resolver)
+		deployer = orDefault(repos.Deployer, deployer)
+	}
+	return writeTechBuildConfig(coreutils.Cocoapods, projectPath, serverId, func(configFile *artifactoryCommandsUtils.ConfigFile) {
+		configFile.Resolver.Repo = resolver
+		configFile.Deployer.Repo = deployer
+	})
+}
+
+func (*cocoapodsInitializer) BuildMessage() string {
+	return "jf pod install\n" +
+		"jf rt u path/to/package/file " + CocoapodsVirtualDefaultName +
+		coreutils.PrintComment(" # Publish your CocoaPods package") +
+		"\n"
+}
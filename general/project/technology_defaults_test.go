@@ -0,0 +1,41 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectByMarkersAtRoot(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "app.csproj"), []byte(""), 0644))
+
+	assert.True(t, detectByMarkers(root, nugetMarkers, false))
+	assert.False(t, detectByMarkers(root, cocoapodsMarkers, false))
+}
+
+func TestDetectByMarkersRecursive(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "src"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "src", "Podfile"), []byte(""), 0644))
+
+	assert.False(t, detectByMarkers(root, cocoapodsMarkers, false))
+	assert.True(t, detectByMarkers(root, cocoapodsMarkers, true))
+}
+
+func TestNugetInitializerDetectFallsBackToMarkers(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "packages.config"), []byte(""), 0644))
+
+	// coreutils.DetectTechnologies won't find anything here, but the marker fallback should.
+	assert.True(t, (&nugetInitializer{}).Detect(root, false))
+}
+
+func TestCocoapodsInitializerDetectFallsBackToMarkers(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "Podfile"), []byte(""), 0644))
+
+	assert.True(t, (&cocoapodsInitializer{}).Detect(root, false))
+}
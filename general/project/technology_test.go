@@ -0,0 +1,41 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeInitializer struct {
+	tech coreutils.Technology
+}
+
+func (f *fakeInitializer) Technology() coreutils.Technology                     { return f.tech }
+func (f *fakeInitializer) Detect(string, bool) bool                             { return true }
+func (f *fakeInitializer) DefaultRepos(string) []RepoSpec                       { return nil }
+func (f *fakeInitializer) WriteBuildConfig(string, string, *RepoOverride) error { return nil }
+func (f *fakeInitializer) BuildMessage() string                                 { return "" }
+
+func TestRegisterTechnologyInitializer(t *testing.T) {
+	defer func(previous map[coreutils.Technology]TechnologyInitializer) {
+		technologyInitializers = previous
+	}(technologyInitializers)
+	technologyInitializers = map[coreutils.Technology]TechnologyInitializer{}
+
+	RegisterTechnologyInitializer(&fakeInitializer{tech: coreutils.Npm})
+	assert.Len(t, GetRegisteredTechnologyInitializers(), 1)
+
+	// Registering the same technology again overrides the previous initializer rather than
+	// accumulating duplicates.
+	second := &fakeInitializer{tech: coreutils.Npm}
+	RegisterTechnologyInitializer(second)
+	initializers := GetRegisteredTechnologyInitializers()
+	assert.Len(t, initializers, 1)
+	assert.Same(t, second, initializers[0])
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.Equal(t, "override", orDefault("override", "fallback"))
+	assert.Equal(t, "fallback", orDefault("", "fallback"))
+}